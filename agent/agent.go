@@ -0,0 +1,122 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"goon/scheduler"
+	"goon/tools"
+)
+
+const defaultMaxToolSteps = 8
+
+// Agent drives a conversation against a Provider, keeping a rolling message
+// history. It also embeds a task Scheduler so tool calls and background
+// work can be submitted alongside the conversation loop.
+//
+// There is no Agent.Run supervisor: the shipped binary is the long-running
+// daemon main.go builds around route.Server and Scheduler directly (see
+// chunk0-3), so Agent's job stops at driving a single conversation/tool
+// loop, and process-level signal handling and bounded shutdown live there.
+type Agent struct {
+	Provider  Provider
+	Scheduler *scheduler.Scheduler
+	Tools     *tools.Registry
+
+	systemPrompt string
+	maxToolSteps int
+
+	mu       sync.Mutex
+	messages []Message
+}
+
+// New builds an Agent around provider. If cfg.SystemPrompt is set it is
+// recorded as the first message in every request sent to the provider.
+// cfg.Workers sizes the embedded Scheduler's worker pool. The returned
+// Agent starts with an empty Tools registry; callers register tools on it
+// before the first Execute.
+func New(cfg Config, provider Provider) *Agent {
+	workers := cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	maxToolSteps := cfg.MaxToolSteps
+	if maxToolSteps <= 0 {
+		maxToolSteps = defaultMaxToolSteps
+	}
+	return &Agent{
+		Provider:     provider,
+		Scheduler:    scheduler.New(workers),
+		Tools:        tools.NewRegistry(),
+		systemPrompt: cfg.SystemPrompt,
+		maxToolSteps: maxToolSteps,
+	}
+}
+
+// Execute appends input as a user turn and drives the provider until it
+// returns a final assistant message, dispatching any tool calls it makes
+// along the way through a.Tools. It is safe for concurrent use.
+func (a *Agent) Execute(ctx context.Context, input string) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	before := len(a.messages)
+	a.messages = append(a.messages, Message{Role: RoleUser, Content: input})
+
+	reply, err := a.step(ctx)
+	if err != nil {
+		// Don't leave a dangling user turn with no reply behind it, nor
+		// any partial tool exchange step recorded after it.
+		a.messages = a.messages[:before]
+		return "", fmt.Errorf("agent: execute: %w", err)
+	}
+	return reply, nil
+}
+
+// step runs the provider/tool-call loop against the current history and
+// returns the final assistant message's content.
+func (a *Agent) step(ctx context.Context) (string, error) {
+	toolSchema := a.Tools.Describe()
+	if string(toolSchema) == "[]" {
+		toolSchema = nil
+	}
+
+	for i := 0; i < a.maxToolSteps; i++ {
+		reply, err := a.Provider.TextToText(ctx, a.history(), toolSchema)
+		if err != nil {
+			return "", err
+		}
+		a.messages = append(a.messages, reply)
+
+		if len(reply.ToolCalls) == 0 {
+			return reply.Content, nil
+		}
+
+		for _, call := range reply.ToolCalls {
+			result, err := a.Tools.Invoke(ctx, call.Name, call.Args)
+			if err != nil {
+				result = []byte(fmt.Sprintf(`{"error":%q}`, err.Error()))
+			}
+			a.messages = append(a.messages, Message{
+				Role:       RoleTool,
+				Content:    string(result),
+				ToolCallID: call.ID,
+			})
+		}
+	}
+
+	return "", fmt.Errorf("exceeded max tool steps (%d)", a.maxToolSteps)
+}
+
+// history returns the messages to send to the provider, including the
+// system prompt if one is configured.
+func (a *Agent) history() []Message {
+	if a.systemPrompt == "" {
+		return a.messages
+	}
+	out := make([]Message, 0, len(a.messages)+1)
+	out = append(out, Message{Role: RoleSystem, Content: a.systemPrompt})
+	out = append(out, a.messages...)
+	return out
+}
@@ -0,0 +1,82 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestAgentExecuteRoundTrip(t *testing.T) {
+	provider := &MockProvider{
+		Reply: func(messages []Message, tools json.RawMessage) (Message, error) {
+			if len(messages) != 1 || messages[0].Content != "hello" {
+				t.Fatalf("unexpected messages sent to provider: %+v", messages)
+			}
+			return Message{Role: RoleAssistant, Content: "hi there"}, nil
+		},
+	}
+
+	a := New(Config{}, provider)
+	got, err := a.Execute(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got != "hi there" {
+		t.Fatalf("Execute() = %q, want %q", got, "hi there")
+	}
+	if len(a.messages) != 2 {
+		t.Fatalf("expected 2 messages recorded, got %d", len(a.messages))
+	}
+}
+
+type echoTool struct{}
+
+func (echoTool) Name() string               { return "echo" }
+func (echoTool) Schema() json.RawMessage    { return json.RawMessage(`{"type":"object"}`) }
+func (echoTool) Invoke(ctx context.Context, args json.RawMessage) (json.RawMessage, error) {
+	return json.RawMessage(`{"said":"hi"}`), nil
+}
+
+func TestAgentExecuteDispatchesToolCalls(t *testing.T) {
+	calls := 0
+	provider := &MockProvider{
+		Reply: func(messages []Message, toolSchema json.RawMessage) (Message, error) {
+			calls++
+			if calls == 1 {
+				if toolSchema == nil {
+					t.Fatalf("expected tool schema to be sent once a tool is registered")
+				}
+				return Message{
+					Role: RoleAssistant,
+					ToolCalls: []ToolCall{
+						{ID: "call-1", Name: "echo", Args: json.RawMessage(`{}`)},
+					},
+				}, nil
+			}
+			var last Message
+			for _, m := range messages {
+				if m.Role == RoleTool {
+					last = m
+				}
+			}
+			if last.Content != `{"said":"hi"}` {
+				t.Fatalf("tool result not threaded back into history, got %+v", last)
+			}
+			return Message{Role: RoleAssistant, Content: "done"}, nil
+		},
+	}
+
+	a := New(Config{}, provider)
+	a.Tools.Register(echoTool{})
+
+	got, err := a.Execute(context.Background(), "use the tool")
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got != "done" {
+		t.Fatalf("Execute() = %q, want %q", got, "done")
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 provider round trips, got %d", calls)
+	}
+}
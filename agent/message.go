@@ -0,0 +1,34 @@
+package agent
+
+import "encoding/json"
+
+// Role identifies who authored a Message in a conversation.
+type Role string
+
+const (
+	RoleSystem    Role = "system"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+	RoleTool      Role = "tool"
+)
+
+// ToolCall is a request from the model, carried on an assistant Message, to
+// invoke a registered tool.
+type ToolCall struct {
+	ID   string
+	Name string
+	Args json.RawMessage
+}
+
+// Message is a single turn in a conversation passed to a Provider.
+type Message struct {
+	Role    Role
+	Content string
+
+	// ToolCalls is set on an assistant Message that wants one or more
+	// tools invoked before the conversation continues.
+	ToolCalls []ToolCall
+	// ToolCallID identifies which ToolCall a RoleTool Message is the
+	// result of.
+	ToolCallID string
+}
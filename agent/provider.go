@@ -0,0 +1,54 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Provider generates the next assistant Message given conversation history.
+// tools is an OpenAI-style tools array (as produced by tools.Registry.Describe)
+// describing the functions the model may call; it is nil if none are
+// registered.
+type Provider interface {
+	TextToText(ctx context.Context, messages []Message, tools json.RawMessage) (Message, error)
+}
+
+// Config holds the settings needed to construct a Provider and drive an Agent.
+type Config struct {
+	Provider     string
+	Model        string
+	APIKey       string
+	BaseURL      string
+	Temperature  float64
+	Timeout      time.Duration
+	SystemPrompt string
+
+	// Workers sizes the scheduler's worker pool. Defaults to 1 if unset.
+	Workers int
+	// MaxToolSteps bounds how many tool-call round trips Execute will make
+	// for a single input before it gives up and returns an error. Defaults
+	// to 8 if unset.
+	MaxToolSteps int
+}
+
+// ProviderFactory builds a Provider from Config. Providers register themselves
+// via RegisterProvider so new backends can be added without touching Agent.
+type ProviderFactory func(cfg Config) (Provider, error)
+
+var providerFactories = map[string]ProviderFactory{}
+
+// RegisterProvider makes a provider available under name for NewProvider.
+func RegisterProvider(name string, factory ProviderFactory) {
+	providerFactories[name] = factory
+}
+
+// NewProvider builds the registered provider named cfg.Provider.
+func NewProvider(cfg Config) (Provider, error) {
+	factory, ok := providerFactories[cfg.Provider]
+	if !ok {
+		return nil, fmt.Errorf("agent: unknown provider %q", cfg.Provider)
+	}
+	return factory(cfg)
+}
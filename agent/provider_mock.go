@@ -0,0 +1,33 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+)
+
+func init() {
+	RegisterProvider("mock", newMockProvider)
+}
+
+// MockProvider is a deterministic Provider for tests and local experimentation.
+// Reply defaults to echoing the last user message if unset.
+type MockProvider struct {
+	Reply func(messages []Message, tools json.RawMessage) (Message, error)
+}
+
+func newMockProvider(cfg Config) (Provider, error) {
+	return &MockProvider{}, nil
+}
+
+func (p *MockProvider) TextToText(ctx context.Context, messages []Message, tools json.RawMessage) (Message, error) {
+	if p.Reply != nil {
+		return p.Reply(messages, tools)
+	}
+	var last Message
+	for _, m := range messages {
+		if m.Role == RoleUser {
+			last = m
+		}
+	}
+	return Message{Role: RoleAssistant, Content: "echo: " + last.Content}, nil
+}
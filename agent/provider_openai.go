@@ -0,0 +1,141 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+func init() {
+	RegisterProvider("openai", newOpenAIProvider)
+}
+
+// openAIProvider talks to any OpenAI-compatible chat completions endpoint.
+type openAIProvider struct {
+	cfg    Config
+	client *http.Client
+}
+
+func newOpenAIProvider(cfg Config) (Provider, error) {
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("agent: openai provider requires an API key")
+	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.openai.com/v1"
+	}
+	cfg.BaseURL = baseURL
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &openAIProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+type chatToolCall struct {
+	ID       string `json:"id"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type chatMessage struct {
+	Role       string         `json:"role"`
+	Content    string         `json:"content"`
+	ToolCalls  []chatToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string         `json:"tool_call_id,omitempty"`
+}
+
+type chatRequest struct {
+	Model       string          `json:"model"`
+	Messages    []chatMessage   `json:"messages"`
+	Temperature float64         `json:"temperature,omitempty"`
+	Tools       json.RawMessage `json:"tools,omitempty"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (p *openAIProvider) TextToText(ctx context.Context, messages []Message, tools json.RawMessage) (Message, error) {
+	reqBody := chatRequest{
+		Model:       p.cfg.Model,
+		Temperature: p.cfg.Temperature,
+		Tools:       tools,
+	}
+	for _, m := range messages {
+		reqBody.Messages = append(reqBody.Messages, toChatMessage(m))
+	}
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return Message{}, fmt.Errorf("agent: encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.BaseURL+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return Message{}, fmt.Errorf("agent: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.cfg.APIKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return Message{}, fmt.Errorf("agent: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Message{}, fmt.Errorf("agent: read response: %w", err)
+	}
+
+	var out chatResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return Message{}, fmt.Errorf("agent: decode response: %w", err)
+	}
+	if out.Error != nil {
+		return Message{}, fmt.Errorf("agent: provider error: %s", out.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK || len(out.Choices) == 0 {
+		return Message{}, fmt.Errorf("agent: unexpected response status %d", resp.StatusCode)
+	}
+
+	return fromChatMessage(out.Choices[0].Message), nil
+}
+
+func toChatMessage(m Message) chatMessage {
+	cm := chatMessage{Role: string(m.Role), Content: m.Content, ToolCallID: m.ToolCallID}
+	for _, tc := range m.ToolCalls {
+		ctc := chatToolCall{ID: tc.ID}
+		ctc.Function.Name = tc.Name
+		ctc.Function.Arguments = string(tc.Args)
+		cm.ToolCalls = append(cm.ToolCalls, ctc)
+	}
+	return cm
+}
+
+func fromChatMessage(cm chatMessage) Message {
+	m := Message{Role: Role(cm.Role), Content: cm.Content, ToolCallID: cm.ToolCallID}
+	for _, ctc := range cm.ToolCalls {
+		m.ToolCalls = append(m.ToolCalls, ToolCall{
+			ID:   ctc.ID,
+			Name: ctc.Function.Name,
+			Args: json.RawMessage(ctc.Function.Arguments),
+		})
+	}
+	return m
+}
@@ -0,0 +1,140 @@
+// Package config loads goon's on-disk configuration file.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Config is the daemon's full on-disk configuration.
+type Config struct {
+	Listen   string
+	LogLevel string
+	// AuthToken gates the HTTP control plane. If empty, the daemon
+	// generates a random one at startup rather than serving unauthenticated.
+	AuthToken string
+
+	Provider struct {
+		Name        string
+		Model       string
+		APIKey      string
+		BaseURL     string
+		Temperature float64
+		Timeout     time.Duration
+	}
+
+	Tasks struct {
+		Workers      int
+		DrainTimeout time.Duration
+	}
+}
+
+// Default returns the configuration used when no file is present.
+func Default() *Config {
+	cfg := &Config{
+		Listen:   ":8080",
+		LogLevel: "info",
+	}
+	cfg.Provider.Name = "mock"
+	cfg.Provider.Model = "gpt-4o-mini"
+	cfg.Provider.Temperature = 0.7
+	cfg.Provider.Timeout = 30 * time.Second
+	cfg.Tasks.Workers = 4
+	cfg.Tasks.DrainTimeout = 30 * time.Second
+	return cfg
+}
+
+// Load reads the config file at path, falling back to Default() fields for
+// anything the file doesn't set. A missing file is not an error: Load
+// returns Default() unchanged.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: read %s: %w", path, err)
+	}
+
+	var sections map[string]map[string]string
+	switch filepath.Ext(path) {
+	case ".json":
+		sections, err = parseJSON(data)
+	default:
+		sections, err = parseTOML(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: parse %s: %w", path, err)
+	}
+
+	if err := apply(cfg, sections); err != nil {
+		return nil, fmt.Errorf("config: %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func apply(cfg *Config, sections map[string]map[string]string) error {
+	top := sections[""]
+	if v, ok := top["listen"]; ok {
+		cfg.Listen = v
+	}
+	if v, ok := top["log_level"]; ok {
+		cfg.LogLevel = v
+	}
+	if v, ok := top["auth_token"]; ok {
+		cfg.AuthToken = v
+	}
+
+	if p, ok := sections["provider"]; ok {
+		if v, ok := p["name"]; ok {
+			cfg.Provider.Name = v
+		}
+		if v, ok := p["model"]; ok {
+			cfg.Provider.Model = v
+		}
+		if v, ok := p["api_key"]; ok {
+			cfg.Provider.APIKey = v
+		}
+		if v, ok := p["base_url"]; ok {
+			cfg.Provider.BaseURL = v
+		}
+		if v, ok := p["temperature"]; ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return fmt.Errorf("provider.temperature: %w", err)
+			}
+			cfg.Provider.Temperature = f
+		}
+		if v, ok := p["timeout"]; ok {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("provider.timeout: %w", err)
+			}
+			cfg.Provider.Timeout = d
+		}
+	}
+
+	if t, ok := sections["tasks"]; ok {
+		if v, ok := t["workers"]; ok {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("tasks.workers: %w", err)
+			}
+			cfg.Tasks.Workers = n
+		}
+		if v, ok := t["drain_timeout"]; ok {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("tasks.drain_timeout: %w", err)
+			}
+			cfg.Tasks.DrainTimeout = d
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadMissingFileReturnsDefault(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.toml"))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Listen != Default().Listen {
+		t.Fatalf("Listen = %q, want default %q", cfg.Listen, Default().Listen)
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goon.toml")
+	contents := `
+listen = ":9090"
+log_level = "debug"
+auth_token = "s3cr3t"
+
+[provider]
+name = "openai"
+model = "gpt-4o"
+temperature = 0.2
+timeout = "10s"
+
+[tasks]
+workers = 8
+drain_timeout = "1m"
+`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Listen != ":9090" || cfg.LogLevel != "debug" || cfg.AuthToken != "s3cr3t" {
+		t.Fatalf("top-level fields not parsed: %+v", cfg)
+	}
+	if cfg.Provider.Name != "openai" || cfg.Provider.Model != "gpt-4o" {
+		t.Fatalf("provider fields not parsed: %+v", cfg.Provider)
+	}
+	if cfg.Provider.Timeout != 10*time.Second {
+		t.Fatalf("Provider.Timeout = %v, want 10s", cfg.Provider.Timeout)
+	}
+	if cfg.Tasks.Workers != 8 || cfg.Tasks.DrainTimeout != time.Minute {
+		t.Fatalf("tasks fields not parsed: %+v", cfg.Tasks)
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// parseJSON flattens a two-level JSON object into the same
+// map[section]map[key]value shape parseTOML produces, stringifying scalar
+// values so apply() can parse them uniformly.
+func parseJSON(data []byte) (map[string]map[string]string, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	sections := map[string]map[string]string{"": {}}
+	for key, val := range raw {
+		if nested, ok := val.(map[string]any); ok {
+			section := map[string]string{}
+			for k, v := range nested {
+				section[k] = stringify(v)
+			}
+			sections[key] = section
+			continue
+		}
+		sections[""][key] = stringify(val)
+	}
+	return sections, nil
+}
+
+func stringify(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// parseTOML understands the small subset of TOML goon's config needs: flat
+// "key = value" pairs, optionally grouped under "[section]" headers, with
+// string values in quotes and bare numbers/booleans/durations otherwise.
+// It is not a general TOML parser. Section "" holds top-level keys.
+func parseTOML(data []byte) (map[string]map[string]string, error) {
+	sections := map[string]map[string]string{"": {}}
+	section := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: malformed section header %q", lineNo, line)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := sections[section]; !ok {
+				sections[section] = map[string]string{}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value, got %q", lineNo, line)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = value[1 : len(value)-1]
+		}
+		sections[section][key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
@@ -1,26 +1,268 @@
 package main
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
+
+	"goon/agent"
+	"goon/config"
+	"goon/route"
+	"goon/tools"
+	"goon/updater"
 )
 
-// Agent is a minimal example of a Go agent. Extend this struct as needed.
-type Agent struct{}
+// version is overridden at build time with -ldflags "-X main.version=...".
+var version = "dev"
+
+func main() {
+	configPath := flag.String("config", "./goon.toml", "path to the goon config file")
+	assetDir := flag.String("asset-dir", ".", "directory for runtime assets (PID file, etc.)")
+	signalCmd := flag.String("s", "", "send a signal to a running goon instance: stop")
+	allowShell := flag.String("allow-shell", "", "comma-separated commands the shell_exec tool may run")
+	fsRoot := flag.String("fs-root", ".", "directory the fs_read tool is rooted at")
+	maxToolSteps := flag.Int("max-tool-steps", 8, "max tool-call round trips per Execute before giving up")
+	agentID := flag.String("agent-id", "", "identifier this instance reports to -update-url")
+	updateURL := flag.String("update-url", "", "control-plane URL to check for newer builds; disabled if empty")
+	updatePublicKey := flag.String("update-public-key", "", "hex-encoded ed25519 public key releases are signed with; required to apply an update")
+	noAutoUpdate := flag.Bool("no-auto-update", false, "disable automatic self-update")
+	updateCheckInterval := flag.Duration("update-check-interval", time.Hour, "how often to poll -update-url for a newer build")
+	flag.Parse()
+
+	if *signalCmd != "" {
+		if err := handleSignalCommand(*signalCmd, *assetDir); err != nil {
+			fmt.Fprintln(os.Stderr, "goon:", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-func (a *Agent) Run() {
-	fmt.Println("Agent is running. Press Ctrl+C to exit.")
-	// Main loop, replace with agent logic
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	fmt.Println("Agent shutting down.")
+	opts := runOptions{
+		configPath:          *configPath,
+		assetDir:            *assetDir,
+		allowShell:          *allowShell,
+		fsRoot:              *fsRoot,
+		maxToolSteps:        *maxToolSteps,
+		agentID:             *agentID,
+		updateURL:           *updateURL,
+		updatePublicKey:     *updatePublicKey,
+		noAutoUpdate:        *noAutoUpdate,
+		updateCheckInterval: *updateCheckInterval,
+	}
+	if err := run(opts); err != nil {
+		fmt.Fprintln(os.Stderr, "goon:", err)
+		os.Exit(1)
+	}
 }
 
-func main() {
-	agent := &Agent{}
-	agent.Run()
+func handleSignalCommand(cmd, assetDir string) error {
+	switch cmd {
+	case "stop":
+		return stopRunning(assetDir)
+	default:
+		return fmt.Errorf("unknown signal command %q", cmd)
+	}
+}
+
+// runOptions are the CLI-level settings layered on top of the config file.
+type runOptions struct {
+	configPath   string
+	assetDir     string
+	allowShell   string
+	fsRoot       string
+	maxToolSteps int
+
+	agentID             string
+	updateURL           string
+	updatePublicKey     string
+	noAutoUpdate        bool
+	updateCheckInterval time.Duration
 }
 
+func newUpdater(opts runOptions) (*updater.Updater, error) {
+	var pub ed25519.PublicKey
+	if opts.updatePublicKey != "" {
+		decoded, err := hex.DecodeString(opts.updatePublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("decode -update-public-key: %w", err)
+		}
+		if len(decoded) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("-update-public-key: got %d bytes, want %d", len(decoded), ed25519.PublicKeySize)
+		}
+		pub = ed25519.PublicKey(decoded)
+	}
+
+	return &updater.Updater{
+		ControlURL:     opts.updateURL,
+		AgentID:        opts.agentID,
+		CurrentVersion: version,
+		PublicKey:      pub,
+		NoAutoUpdate:   opts.noAutoUpdate || opts.updateURL == "",
+		CheckInterval:  opts.updateCheckInterval,
+	}, nil
+}
+
+func run(opts runOptions) error {
+	cfg, err := config.Load(opts.configPath)
+	if err != nil {
+		return err
+	}
+
+	if err := writePIDFile(opts.assetDir); err != nil {
+		return fmt.Errorf("write pid file: %w", err)
+	}
+	// Set once an update is applied: the re-exec'd child has already
+	// written its own PID file by the time we'd remove ours.
+	updated := false
+	defer func() {
+		if !updated {
+			removePIDFile(opts.assetDir)
+		}
+	}()
+
+	up, err := newUpdater(opts)
+	if err != nil {
+		return err
+	}
+	if err := up.CheckAndApply(context.Background()); err != nil {
+		if errors.Is(err, updater.ErrUpdated) {
+			updated = true
+			return nil
+		}
+		fmt.Fprintln(os.Stderr, "goon: startup update check failed:", err)
+	}
+
+	providerCfg := agent.Config{
+		Provider:     cfg.Provider.Name,
+		Model:        cfg.Provider.Model,
+		APIKey:       cfg.Provider.APIKey,
+		BaseURL:      cfg.Provider.BaseURL,
+		Temperature:  cfg.Provider.Temperature,
+		Timeout:      cfg.Provider.Timeout,
+		Workers:      cfg.Tasks.Workers,
+		MaxToolSteps: opts.maxToolSteps,
+	}
+	provider, err := agent.NewProvider(providerCfg)
+	if err != nil {
+		return err
+	}
+	a := agent.New(providerCfg, provider)
+	registerTools(a, opts)
+
+	authToken := cfg.AuthToken
+	if authToken == "" {
+		authToken, err = generateAuthToken()
+		if err != nil {
+			return fmt.Errorf("generate auth token: %w", err)
+		}
+		fmt.Printf("goon: no auth_token configured, generated one for this run: %s\n", authToken)
+	}
+
+	srv := route.New(cfg.Listen, a, version, authToken)
+
+	// A self-update re-exec hands its listening socket down via
+	// route.ListenFDEnv rather than letting the child reacquire the
+	// address from scratch, which would always lose the race against this
+	// still-serving parent. Bind (or inherit) before RunPeriodic can fire a
+	// re-exec so up.HandoffFile always has a live listener to duplicate.
+	if l, err := route.InheritedListener(); err != nil {
+		return fmt.Errorf("inherit listener: %w", err)
+	} else if l != nil {
+		srv.SetListener(l)
+	}
+	if _, err := srv.Listen(); err != nil {
+		return fmt.Errorf("listen on %s: %w", cfg.Listen, err)
+	}
+	up.HandoffFile = srv.ListenerFile
+	up.HandoffFDEnv = route.ListenFDEnv
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go up.RunPeriodic(ctx, func() {
+		updated = true
+		cancel()
+	}, func(err error) {
+		fmt.Fprintln(os.Stderr, "goon: update check failed:", err)
+	})
+
+	// The scheduler runs on its own background context; shutdown is driven
+	// explicitly via Drain below so it gets a bounded grace period instead
+	// of running until every queued task drains on its own.
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	schedulerDone := make(chan struct{})
+	go func() {
+		a.Scheduler.Start(schedulerCtx)
+		close(schedulerDone)
+	}()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serveErr <- err
+		}
+		close(serveErr)
+	}()
+
+	fmt.Printf("goon listening on %s\n", cfg.Listen)
+
+	select {
+	case <-ctx.Done():
+	case err := <-serveErr:
+		if err != nil {
+			return err
+		}
+	}
+
+	shutdownTimeout := cfg.Tasks.DrainTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = 30 * time.Second
+	}
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		fmt.Fprintln(os.Stderr, "goon: http shutdown:", err)
+	}
+	if err := a.Scheduler.Drain(shutdownCtx); err != nil {
+		fmt.Fprintln(os.Stderr, "goon: scheduler drain:", err)
+	}
+	stopScheduler()
+	<-schedulerDone
+
+	return nil
+}
+
+// generateAuthToken returns a random hex token used to gate the HTTP control
+// plane when no auth_token is configured.
+func generateAuthToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// registerTools wires up goon's built-in tools according to opts. shell_exec
+// is only registered if an allowlist was provided; http_get and fs_read are
+// always available, with fs_read rooted at opts.fsRoot.
+func registerTools(a *agent.Agent, opts runOptions) {
+	if opts.allowShell != "" {
+		a.Tools.Register(&tools.ShellExec{Allowed: strings.Split(opts.allowShell, ",")})
+	}
+	a.Tools.Register(&tools.HTTPGet{})
+	a.Tools.Register(&tools.FSRead{Dir: opts.fsRoot})
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+func pidFilePath(assetDir string) string {
+	return filepath.Join(assetDir, "goon.pid")
+}
+
+// writePIDFile records the current process's PID so a later `-s stop`
+// invocation can find it.
+func writePIDFile(assetDir string) error {
+	if err := os.MkdirAll(assetDir, 0o755); err != nil {
+		return fmt.Errorf("create asset dir: %w", err)
+	}
+	return os.WriteFile(pidFilePath(assetDir), []byte(strconv.Itoa(os.Getpid())), 0o644)
+}
+
+// removePIDFile cleans up the file written by writePIDFile. A missing file
+// is not an error since it may have already been removed.
+func removePIDFile(assetDir string) error {
+	err := os.Remove(pidFilePath(assetDir))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// stopRunning reads the PID file under assetDir and sends SIGTERM to that
+// process, mirroring the `-s stop` control path other long-running daemons
+// in this style expose.
+func stopRunning(assetDir string) error {
+	data, err := os.ReadFile(pidFilePath(assetDir))
+	if err != nil {
+		return fmt.Errorf("read pid file: %w", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return fmt.Errorf("parse pid file: %w", err)
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return fmt.Errorf("find process %d: %w", pid, err)
+	}
+	if err := proc.Signal(syscall.SIGTERM); err != nil {
+		return fmt.Errorf("signal process %d: %w", pid, err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package route
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// requireToken wraps next so it only runs if the request carries
+// "Authorization: Bearer <token>" matching token exactly. /healthz is
+// exempt so orchestrators/load balancers can probe it without credentials;
+// every other route on the control plane needs the token.
+func requireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		const prefix = "Bearer "
+		header := r.Header.Get("Authorization")
+		if !strings.HasPrefix(header, prefix) {
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+		presented := strings.TrimPrefix(header, prefix)
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			http.Error(w, "invalid bearer token", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,36 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type chatRequest struct {
+	Message string `json:"message"`
+}
+
+type chatResponse struct {
+	Reply string `json:"reply"`
+}
+
+func (s *Server) handleChat(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req chatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	reply, err := s.agent.Execute(r.Context(), req.Message)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, chatResponse{Reply: reply})
+}
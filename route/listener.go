@@ -0,0 +1,34 @@
+package route
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+)
+
+// ListenFDEnv is the environment variable a self-update re-exec sets to hand
+// an already-open listening socket down to the replacement binary (see
+// Server.ListenerFile). Its value is the inherited file descriptor number.
+const ListenFDEnv = "GOON_LISTEN_FD"
+
+// InheritedListener returns the listener passed down via ListenFDEnv, or nil
+// if the process wasn't started with one. Callers should SetListener with
+// the result before calling Server.ListenAndServe.
+func InheritedListener() (net.Listener, error) {
+	v := os.Getenv(ListenFDEnv)
+	if v == "" {
+		return nil, nil
+	}
+	fd, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, fmt.Errorf("route: parse %s: %w", ListenFDEnv, err)
+	}
+	f := os.NewFile(uintptr(fd), "goon-inherited-listener")
+	l, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("route: inherit listener fd %d: %w", fd, err)
+	}
+	f.Close()
+	return l, nil
+}
@@ -0,0 +1,68 @@
+package route
+
+import (
+	"net"
+	"strconv"
+	"testing"
+)
+
+func TestInheritedListenerAbsent(t *testing.T) {
+	t.Setenv(ListenFDEnv, "")
+	l, err := InheritedListener()
+	if err != nil {
+		t.Fatalf("InheritedListener returned error: %v", err)
+	}
+	if l != nil {
+		t.Fatalf("expected nil listener when %s is unset", ListenFDEnv)
+	}
+}
+
+// TestListenerFileRoundTrip confirms a listener handed off via its *os.File
+// (the same mechanism used to pass a socket to a re-exec'd child) is usable
+// on the receiving end: accepting a connection on the duplicate works just
+// like the original.
+func TestListenerFileRoundTrip(t *testing.T) {
+	s := newTestServer(t)
+
+	l, err := s.Listen()
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	addr := l.Addr().String()
+
+	f, err := s.ListenerFile()
+	if err != nil {
+		t.Fatalf("ListenerFile: %v", err)
+	}
+	defer f.Close()
+
+	t.Setenv(ListenFDEnv, strconv.Itoa(int(f.Fd())))
+	inherited, err := InheritedListener()
+	if err != nil {
+		t.Fatalf("InheritedListener: %v", err)
+	}
+	defer inherited.Close()
+
+	if inherited.Addr().String() != addr {
+		t.Fatalf("inherited listener addr = %q, want %q", inherited.Addr().String(), addr)
+	}
+
+	accepted := make(chan error, 1)
+	go func() {
+		conn, err := inherited.Accept()
+		if err == nil {
+			conn.Close()
+		}
+		accepted <- err
+	}()
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial inherited listener: %v", err)
+	}
+	conn.Close()
+
+	if err := <-accepted; err != nil {
+		t.Fatalf("Accept on inherited listener: %v", err)
+	}
+}
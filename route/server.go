@@ -0,0 +1,100 @@
+// Package route wires the agent's HTTP control plane: health checks,
+// version info, task management, and the chat endpoint.
+package route
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"goon/agent"
+)
+
+// Server is goon's HTTP control plane.
+type Server struct {
+	Version string
+
+	agent    *agent.Agent
+	tasks    *taskRegistry
+	httpSrv  *http.Server
+	listener net.Listener
+}
+
+// New builds a Server listening on addr, backed by a. authToken gates every
+// route except /healthz behind "Authorization: Bearer <authToken>"; callers
+// must not pass an empty authToken (New panics if they do), so the control
+// plane is never exposed unauthenticated by default.
+func New(addr string, a *agent.Agent, version, authToken string) *Server {
+	if authToken == "" {
+		panic("route: authToken must not be empty")
+	}
+
+	s := &Server{
+		Version: version,
+		agent:   a,
+		tasks:   newTaskRegistry(a.Scheduler),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/version", s.handleVersion)
+	mux.HandleFunc("/tasks", s.handleTasks)
+	mux.HandleFunc("/agent/chat", s.handleChat)
+
+	s.httpSrv = &http.Server{Addr: addr, Handler: requireToken(authToken, mux)}
+	return s
+}
+
+// Listen binds the server's listening socket without starting to serve, so
+// a caller that needs the socket's file descriptor ahead of time (see
+// ListenerFile) can get it before traffic starts flowing. It is a no-op if
+// SetListener already supplied one (e.g. inherited across a self-update
+// re-exec). ListenAndServe calls this itself if the caller hasn't already.
+func (s *Server) Listen() (net.Listener, error) {
+	if s.listener != nil {
+		return s.listener, nil
+	}
+	l, err := net.Listen("tcp", s.httpSrv.Addr)
+	if err != nil {
+		return nil, err
+	}
+	s.listener = l
+	return l, nil
+}
+
+// SetListener supplies a listener the server should serve on instead of
+// binding its own, e.g. one inherited from InheritedListener across a
+// self-update re-exec. It must be called before ListenAndServe.
+func (s *Server) SetListener(l net.Listener) {
+	s.listener = l
+}
+
+// ListenerFile returns a duplicate of the server's listening socket as an
+// *os.File, suitable for passing to a child process via exec.Cmd.ExtraFiles
+// so it can inherit the exact same socket across a self-update re-exec
+// without racing the still-serving parent for the port. Listen (or
+// ListenAndServe) must be called first.
+func (s *Server) ListenerFile() (*os.File, error) {
+	tl, ok := s.listener.(*net.TCPListener)
+	if !ok {
+		return nil, fmt.Errorf("route: no TCP listener bound yet")
+	}
+	return tl.File()
+}
+
+// ListenAndServe starts the HTTP server. It blocks until the server stops
+// and returns http.ErrServerClosed on a clean Shutdown.
+func (s *Server) ListenAndServe() error {
+	l, err := s.Listen()
+	if err != nil {
+		return err
+	}
+	return s.httpSrv.Serve(l)
+}
+
+// Shutdown gracefully stops the HTTP server, bounded by ctx.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpSrv.Shutdown(ctx)
+}
@@ -0,0 +1,90 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"goon/agent"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	cfg := agent.Config{Provider: "mock"}
+	provider, err := agent.NewProvider(cfg)
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	a := agent.New(cfg, provider)
+	return New(":0", a, "test", "test-token")
+}
+
+func TestHealthzAndVersion(t *testing.T) {
+	s := newTestServer(t)
+
+	rec := httptest.NewRecorder()
+	s.handleHealthz(rec, httptest.NewRequest("GET", "/healthz", nil))
+	if rec.Code != 200 {
+		t.Fatalf("healthz status = %d, want 200", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	s.handleVersion(rec, httptest.NewRequest("GET", "/version", nil))
+	var body map[string]string
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode version response: %v", err)
+	}
+	if body["version"] != "test" {
+		t.Fatalf("version = %q, want %q", body["version"], "test")
+	}
+}
+
+func TestAuthRequiredExceptHealthz(t *testing.T) {
+	s := newTestServer(t)
+
+	rec := httptest.NewRecorder()
+	s.httpSrv.Handler.ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+	if rec.Code != 200 {
+		t.Fatalf("unauthenticated /healthz status = %d, want 200", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	s.httpSrv.Handler.ServeHTTP(rec, httptest.NewRequest("GET", "/version", nil))
+	if rec.Code != 401 {
+		t.Fatalf("unauthenticated /version status = %d, want 401", rec.Code)
+	}
+
+	req := httptest.NewRequest("GET", "/version", nil)
+	req.Header.Set("Authorization", "Bearer wrong-token")
+	rec = httptest.NewRecorder()
+	s.httpSrv.Handler.ServeHTTP(rec, req)
+	if rec.Code != 401 {
+		t.Fatalf("wrong-token /version status = %d, want 401", rec.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/version", nil)
+	req.Header.Set("Authorization", "Bearer test-token")
+	rec = httptest.NewRecorder()
+	s.httpSrv.Handler.ServeHTTP(rec, req)
+	if rec.Code != 200 {
+		t.Fatalf("correct-token /version status = %d, want 200", rec.Code)
+	}
+}
+
+func TestChatHandler(t *testing.T) {
+	s := newTestServer(t)
+
+	payload, _ := json.Marshal(chatRequest{Message: "hi"})
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/agent/chat", bytes.NewReader(payload))
+	s.handleChat(rec, req)
+
+	var resp chatResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode chat response: %v", err)
+	}
+	if resp.Reply != "echo: hi" {
+		t.Fatalf("Reply = %q, want %q", resp.Reply, "echo: hi")
+	}
+}
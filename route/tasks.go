@@ -0,0 +1,160 @@
+package route
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"goon/scheduler"
+)
+
+// taskStatus tracks the lifecycle of a task submitted over HTTP. The
+// scheduler itself only knows about queued/running; the registry layers
+// completion and cancellation on top so /tasks has something to report.
+type taskStatus string
+
+const (
+	statusQueued    taskStatus = "queued"
+	statusRunning   taskStatus = "running"
+	statusDone      taskStatus = "done"
+	statusFailed    taskStatus = "failed"
+	statusCancelled taskStatus = "cancelled"
+)
+
+type taskEntry struct {
+	ID       string     `json:"id"`
+	Priority int        `json:"priority"`
+	Status   taskStatus `json:"status"`
+	cancel   context.CancelFunc
+}
+
+// taskRegistry records HTTP-submitted tasks so they can be listed and
+// cancelled by ID, delegating actual execution to a scheduler.Scheduler.
+type taskRegistry struct {
+	scheduler *scheduler.Scheduler
+
+	mu      sync.Mutex
+	entries map[string]*taskEntry
+}
+
+func newTaskRegistry(s *scheduler.Scheduler) *taskRegistry {
+	return &taskRegistry{scheduler: s, entries: make(map[string]*taskEntry)}
+}
+
+func (tr *taskRegistry) list() []*taskEntry {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	out := make([]*taskEntry, 0, len(tr.entries))
+	for _, e := range tr.entries {
+		out = append(out, e)
+	}
+	return out
+}
+
+// submitRequest is the payload POSTed to /tasks. SleepMillis is a stand-in
+// unit of work: a task submitted here only sleeps and does not dispatch to
+// the agent's registered tools.
+type submitRequest struct {
+	ID          string `json:"id"`
+	Priority    int    `json:"priority"`
+	SleepMillis int    `json:"sleep_ms"`
+}
+
+func (tr *taskRegistry) submit(req submitRequest) error {
+	if req.ID == "" {
+		return errors.New("task id is required")
+	}
+
+	taskCtx, cancel := context.WithCancel(context.Background())
+	entry := &taskEntry{ID: req.ID, Priority: req.Priority, Status: statusQueued, cancel: cancel}
+
+	tr.mu.Lock()
+	if _, exists := tr.entries[req.ID]; exists {
+		tr.mu.Unlock()
+		cancel()
+		return fmt.Errorf("task %q already exists", req.ID)
+	}
+	tr.entries[req.ID] = entry
+	tr.mu.Unlock()
+
+	err := tr.scheduler.Submit(scheduler.Task{
+		ID:       req.ID,
+		Priority: req.Priority,
+		// Run reacts to both the registry's own per-task cancellation (an
+		// explicit DELETE /tasks?id=) and the scheduler-supplied ctx, which
+		// is cancelled when Scheduler.Drain's deadline forces a shutdown;
+		// without the latter, a long-sleeping task would keep the worker
+		// (and s.wg.Wait() in Drain/Start) blocked forever past shutdown.
+		Run: func(ctx context.Context, _ scheduler.Held) error {
+			tr.setStatus(req.ID, statusRunning)
+			select {
+			case <-time.After(time.Duration(req.SleepMillis) * time.Millisecond):
+				tr.setStatus(req.ID, statusDone)
+			case <-taskCtx.Done():
+				tr.setStatus(req.ID, statusCancelled)
+			case <-ctx.Done():
+				tr.setStatus(req.ID, statusCancelled)
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		tr.mu.Lock()
+		delete(tr.entries, req.ID)
+		tr.mu.Unlock()
+		cancel()
+		return err
+	}
+	return nil
+}
+
+func (tr *taskRegistry) cancel(id string) error {
+	tr.mu.Lock()
+	entry, ok := tr.entries[id]
+	tr.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("task %q not found", id)
+	}
+	entry.cancel()
+	return nil
+}
+
+func (tr *taskRegistry) setStatus(id string, status taskStatus) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if entry, ok := tr.entries[id]; ok {
+		entry.Status = status
+	}
+}
+
+func (s *Server) handleTasks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.tasks.list())
+	case http.MethodPost:
+		var req submitRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := s.tasks.submit(req); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, http.StatusAccepted, map[string]string{"id": req.ID, "status": string(statusQueued)})
+	case http.MethodDelete:
+		id := r.URL.Query().Get("id")
+		if err := s.tasks.cancel(id); err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"id": id, "status": string(statusCancelled)})
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,57 @@
+package route
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"goon/scheduler"
+)
+
+// TestTaskRunHonorsSchedulerContext confirms a long-sleeping task reacts to
+// the scheduler-supplied ctx, not just its own per-task cancel, so
+// Scheduler.Drain's deadline can actually force it to stop instead of
+// leaving the worker (and s.wg.Wait()) blocked forever.
+func TestTaskRunHonorsSchedulerContext(t *testing.T) {
+	s := scheduler.New(1)
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	schedulerDone := make(chan struct{})
+	go func() {
+		s.Start(workerCtx)
+		close(schedulerDone)
+	}()
+
+	tr := newTaskRegistry(s)
+	if err := tr.submit(submitRequest{ID: "long-sleep", SleepMillis: 3_600_000}); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	// Give the worker a moment to pick the task up and mark it running.
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		tr.mu.Lock()
+		status := tr.entries["long-sleep"].Status
+		tr.mu.Unlock()
+		if status == statusRunning {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	stopWorkers()
+
+	select {
+	case <-schedulerDone:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after its worker context was cancelled; task ignored ctx")
+	}
+
+	tr.mu.Lock()
+	status := tr.entries["long-sleep"].Status
+	tr.mu.Unlock()
+	if status != statusCancelled {
+		t.Fatalf("task status = %q, want %q", status, statusCancelled)
+	}
+}
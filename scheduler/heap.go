@@ -0,0 +1,41 @@
+package scheduler
+
+import "container/heap"
+
+// queuedTask wraps a Task with its position in the priority heap so
+// SetPriority can find and fix it up in place.
+type queuedTask struct {
+	task  Task
+	index int
+}
+
+// taskHeap is a max-heap on Task.Priority, implementing container/heap.Interface.
+type taskHeap []*queuedTask
+
+func (h taskHeap) Len() int { return len(h) }
+
+func (h taskHeap) Less(i, j int) bool { return h[i].task.Priority > h[j].task.Priority }
+
+func (h taskHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *taskHeap) Push(x any) {
+	qt := x.(*queuedTask)
+	qt.index = len(*h)
+	*h = append(*h, qt)
+}
+
+func (h *taskHeap) Pop() any {
+	old := *h
+	n := len(old)
+	qt := old[n-1]
+	old[n-1] = nil
+	qt.index = -1
+	*h = old[:n-1]
+	return qt
+}
+
+var _ heap.Interface = (*taskHeap)(nil)
@@ -0,0 +1,27 @@
+package scheduler
+
+import "sync/atomic"
+
+// Metrics is a point-in-time snapshot of scheduler activity.
+type Metrics struct {
+	Queued           int64
+	Running          int64
+	Completed        int64
+	WaitedOnResource int64
+}
+
+type metrics struct {
+	queued           atomic.Int64
+	running          atomic.Int64
+	completed        atomic.Int64
+	waitedOnResource atomic.Int64
+}
+
+func (m *metrics) snapshot() Metrics {
+	return Metrics{
+		Queued:           m.queued.Load(),
+		Running:          m.running.Load(),
+		Completed:        m.completed.Load(),
+		WaitedOnResource: m.waitedOnResource.Load(),
+	}
+}
@@ -0,0 +1,111 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// resourceLock guards concurrent access to a single named resource,
+// allowing any number of Shared holders or exactly one Exclusive holder.
+type resourceLock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	sharers int
+	locked  bool
+}
+
+func newResourceLock() *resourceLock {
+	rl := &resourceLock{}
+	rl.cond = sync.NewCond(&rl.mu)
+	return rl
+}
+
+func (rl *resourceLock) acquire(ctx context.Context, mode ResourceMode) bool {
+	// sync.Cond.Wait has no way to select on ctx.Done(), so a waiter
+	// blocked here would otherwise only wake on another holder's
+	// release(). Run a watchdog that broadcasts on cancellation too, and
+	// stop it as soon as this call returns so it doesn't outlive us.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			rl.cond.Broadcast()
+		case <-stop:
+		}
+	}()
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	for rl.locked || (mode == Exclusive && rl.sharers > 0) {
+		if ctx.Err() != nil {
+			return false
+		}
+		rl.cond.Wait()
+	}
+	if mode == Exclusive {
+		rl.locked = true
+	} else {
+		rl.sharers++
+	}
+	return true
+}
+
+func (rl *resourceLock) release(mode ResourceMode) {
+	rl.mu.Lock()
+	if mode == Exclusive {
+		rl.locked = false
+	} else {
+		rl.sharers--
+	}
+	rl.mu.Unlock()
+	rl.cond.Broadcast()
+}
+
+// resourceTable hands out resourceLocks by name, creating them lazily.
+type resourceTable struct {
+	mu        sync.Mutex
+	resources map[string]*resourceLock
+}
+
+func newResourceTable() *resourceTable {
+	return &resourceTable{resources: make(map[string]*resourceLock)}
+}
+
+func (rt *resourceTable) get(name string) *resourceLock {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rl, ok := rt.resources[name]
+	if !ok {
+		rl = newResourceLock()
+		rt.resources[name] = rl
+	}
+	return rl
+}
+
+// acquireAll locks every requested resource in a stable, name-sorted order
+// to avoid deadlocking against another task that wants the same set. It
+// blocks until ctx is done or every resource is held, returning false (and
+// releasing anything it already acquired) if ctx was cancelled first.
+func (rt *resourceTable) acquireAll(ctx context.Context, reqs []ResourceRequest) bool {
+	ordered := make([]ResourceRequest, len(reqs))
+	copy(ordered, reqs)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].Name < ordered[j].Name })
+
+	acquired := make([]ResourceRequest, 0, len(ordered))
+	for _, req := range ordered {
+		if !rt.get(req.Name).acquire(ctx, req.Mode) {
+			rt.releaseAll(acquired)
+			return false
+		}
+		acquired = append(acquired, req)
+	}
+	return true
+}
+
+func (rt *resourceTable) releaseAll(reqs []ResourceRequest) {
+	for _, req := range reqs {
+		rt.get(req.Name).release(req.Mode)
+	}
+}
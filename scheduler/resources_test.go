@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestResourceLockAcquireWakesOnContextCancel(t *testing.T) {
+	rl := newResourceLock()
+	if !rl.acquire(context.Background(), Exclusive) {
+		t.Fatal("first acquire should succeed immediately")
+	}
+	// Never released: simulates a task that hangs while holding the
+	// resource, which is exactly what a blocked waiter needs to survive.
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan bool, 1)
+	go func() { done <- rl.acquire(ctx, Exclusive) }()
+
+	// Give the second acquire time to actually block on cond.Wait.
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case ok := <-done:
+		if ok {
+			t.Fatal("acquire should report failure once ctx is cancelled")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("acquire did not wake up after ctx was cancelled; waiter is wedged")
+	}
+}
@@ -0,0 +1,180 @@
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Scheduler runs submitted Tasks across a fixed worker pool, honoring task
+// priority and per-resource exclusivity.
+type Scheduler struct {
+	workers   int
+	resources *resourceTable
+	metrics   metrics
+
+	mu       sync.Mutex
+	cond     *sync.Cond
+	heap     taskHeap
+	index    map[string]*queuedTask
+	draining bool
+
+	wg sync.WaitGroup
+}
+
+// New creates a Scheduler with the given number of concurrent workers.
+func New(workers int) *Scheduler {
+	if workers < 1 {
+		workers = 1
+	}
+	s := &Scheduler{
+		workers:   workers,
+		resources: newResourceTable(),
+		index:     make(map[string]*queuedTask),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// Submit enqueues t to run once its resources are available and a worker is
+// free. It returns an error if a task with the same ID is already queued.
+func (s *Scheduler) Submit(t Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.draining {
+		return fmt.Errorf("scheduler: draining, rejecting task %q", t.ID)
+	}
+	if _, exists := s.index[t.ID]; exists {
+		return fmt.Errorf("scheduler: task %q already queued", t.ID)
+	}
+
+	qt := &queuedTask{task: t}
+	heap.Push(&s.heap, qt)
+	s.index[t.ID] = qt
+	s.metrics.queued.Add(1)
+	s.cond.Signal()
+	return nil
+}
+
+// SetPriority re-prioritizes a still-queued task, waking workers so the new
+// ordering is picked up immediately. It is a no-op error if the task has
+// already started running or doesn't exist.
+func (s *Scheduler) SetPriority(id string, newPriority int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	qt, ok := s.index[id]
+	if !ok {
+		return fmt.Errorf("scheduler: task %q not queued", id)
+	}
+	qt.task.Priority = newPriority
+	heap.Fix(&s.heap, qt.index)
+	s.cond.Signal()
+	return nil
+}
+
+// Metrics returns a snapshot of current scheduler activity.
+func (s *Scheduler) Metrics() Metrics {
+	return s.metrics.snapshot()
+}
+
+// Start launches the worker pool. It blocks until ctx is cancelled, at which
+// point it stops admitting new work and waits for running tasks to finish.
+func (s *Scheduler) Start(ctx context.Context) {
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.worker(ctx)
+	}
+	<-ctx.Done()
+
+	s.mu.Lock()
+	s.draining = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// Drain stops admitting new tasks and waits for running tasks to finish,
+// up to the deadline on ctx; any tasks still queued when the deadline
+// passes are dropped.
+func (s *Scheduler) Drain(ctx context.Context) error {
+	s.mu.Lock()
+	s.draining = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		for s.heap.Len() > 0 {
+			qt := heap.Pop(&s.heap).(*queuedTask)
+			delete(s.index, qt.task.ID)
+			s.metrics.queued.Add(-1)
+		}
+		s.mu.Unlock()
+		return fmt.Errorf("scheduler: drain deadline exceeded: %w", ctx.Err())
+	}
+}
+
+// next pops the highest priority task still queued, blocking until one is
+// available, the scheduler starts draining, or ctx is cancelled.
+func (s *Scheduler) next(ctx context.Context) (Task, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for s.heap.Len() == 0 || ctx.Err() != nil {
+		if s.draining || ctx.Err() != nil {
+			return Task{}, false
+		}
+		s.cond.Wait()
+	}
+	qt := heap.Pop(&s.heap).(*queuedTask)
+	delete(s.index, qt.task.ID)
+	s.metrics.queued.Add(-1)
+	return qt.task, true
+}
+
+func (s *Scheduler) worker(ctx context.Context) {
+	defer s.wg.Done()
+	for {
+		task, ok := s.next(ctx)
+		if !ok {
+			return
+		}
+		s.run(ctx, task)
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, task Task) {
+	if len(task.Resources) > 0 {
+		s.metrics.waitedOnResource.Add(1)
+		if !s.resources.acquireAll(ctx, task.Resources) {
+			s.metrics.waitedOnResource.Add(-1)
+			return
+		}
+		s.metrics.waitedOnResource.Add(-1)
+		defer s.resources.releaseAll(task.Resources)
+	}
+
+	held := make(Held, len(task.Resources))
+	for _, r := range task.Resources {
+		held[r.Name] = r.Mode
+	}
+
+	s.metrics.running.Add(1)
+	defer s.metrics.running.Add(-1)
+
+	_ = task.Run(ctx, held)
+	s.metrics.completed.Add(1)
+}
@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSchedulerRunsAllTasks(t *testing.T) {
+	s := New(4)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var mu sync.Mutex
+	var ran []string
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	go s.Start(ctx)
+	for _, id := range []string{"a", "b", "c"} {
+		id := id
+		err := s.Submit(Task{
+			ID:       id,
+			Priority: 1,
+			Run: func(ctx context.Context, held Held) error {
+				mu.Lock()
+				ran = append(ran, id)
+				mu.Unlock()
+				wg.Done()
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("Submit(%s) error: %v", id, err)
+		}
+	}
+
+	waitWithTimeout(t, &wg, time.Second)
+	cancel()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(ran) != 3 {
+		t.Fatalf("expected 3 tasks to run, got %d (%v)", len(ran), ran)
+	}
+}
+
+func TestSchedulerExclusiveResourceSerializes(t *testing.T) {
+	s := New(4)
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.Start(ctx)
+	defer cancel()
+
+	var active int32
+	var maxActive int32
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(5)
+
+	for i := 0; i < 5; i++ {
+		err := s.Submit(Task{
+			ID:        taskID(i),
+			Priority:  1,
+			Resources: []ResourceRequest{{Name: "db", Mode: Exclusive}},
+			Run: func(ctx context.Context, held Held) error {
+				mu.Lock()
+				active++
+				if active > maxActive {
+					maxActive = active
+				}
+				mu.Unlock()
+
+				time.Sleep(5 * time.Millisecond)
+
+				mu.Lock()
+				active--
+				mu.Unlock()
+				wg.Done()
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("Submit error: %v", err)
+		}
+	}
+
+	waitWithTimeout(t, &wg, 2*time.Second)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if maxActive != 1 {
+		t.Fatalf("expected exclusive resource to serialize tasks, saw %d concurrent", maxActive)
+	}
+}
+
+func taskID(i int) string {
+	return "task-" + string(rune('a'+i))
+}
+
+func waitWithTimeout(t *testing.T, wg *sync.WaitGroup, d time.Duration) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(d):
+		t.Fatal("timed out waiting for tasks to complete")
+	}
+}
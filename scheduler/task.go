@@ -0,0 +1,34 @@
+package scheduler
+
+import "context"
+
+// ResourceMode controls whether a task needs exclusive or shared access to
+// a resource it declares.
+type ResourceMode int
+
+const (
+	// Shared allows any number of tasks holding Shared on the same
+	// resource to run concurrently.
+	Shared ResourceMode = iota
+	// Exclusive prevents any other task, Shared or Exclusive, from
+	// holding the same resource at the same time.
+	Exclusive
+)
+
+// ResourceRequest declares a resource a Task needs before it can run.
+type ResourceRequest struct {
+	Name string
+	Mode ResourceMode
+}
+
+// Held is the set of resource names a Task currently holds, passed into
+// Task.Run so callers can confirm what they were granted.
+type Held map[string]ResourceMode
+
+// Task is a unit of work submitted to the Scheduler.
+type Task struct {
+	ID        string
+	Priority  int
+	Resources []ResourceRequest
+	Run       func(ctx context.Context, held Held) error
+}
@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FSRead reads files rooted at Dir. Paths that would escape Dir via ".."
+// or an absolute path are rejected.
+type FSRead struct {
+	Dir string
+}
+
+func (t *FSRead) Name() string { return "fs_read" }
+
+func (t *FSRead) Schema() json.RawMessage {
+	return json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"path": {"type": "string", "description": "path relative to the tool's configured root"}
+		},
+		"required": ["path"]
+	}`)
+}
+
+type fsReadArgs struct {
+	Path string `json:"path"`
+}
+
+type fsReadResult struct {
+	Content string `json:"content"`
+}
+
+func (t *FSRead) Invoke(ctx context.Context, args json.RawMessage) (json.RawMessage, error) {
+	var in fsReadArgs
+	if err := json.Unmarshal(args, &in); err != nil {
+		return nil, fmt.Errorf("fs_read: decode args: %w", err)
+	}
+
+	full, err := t.resolve(in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(full)
+	if err != nil {
+		return nil, fmt.Errorf("fs_read: %w", err)
+	}
+	return json.Marshal(fsReadResult{Content: string(content)})
+}
+
+// resolve joins path onto Dir and rejects anything that escapes it.
+func (t *FSRead) resolve(path string) (string, error) {
+	if filepath.IsAbs(path) {
+		return "", fmt.Errorf("fs_read: path %q must be relative", path)
+	}
+	full := filepath.Join(t.Dir, path)
+	root, err := filepath.Abs(t.Dir)
+	if err != nil {
+		return "", fmt.Errorf("fs_read: resolve root: %w", err)
+	}
+	absFull, err := filepath.Abs(full)
+	if err != nil {
+		return "", fmt.Errorf("fs_read: resolve path: %w", err)
+	}
+	if absFull != root && !strings.HasPrefix(absFull, root+string(os.PathSeparator)) {
+		return "", fmt.Errorf("fs_read: path %q escapes root %q", path, t.Dir)
+	}
+	return absFull, nil
+}
@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFSReadReturnsContent(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hi"), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	tool := &FSRead{Dir: dir}
+	args, _ := json.Marshal(fsReadArgs{Path: "hello.txt"})
+	out, err := tool.Invoke(context.Background(), args)
+	if err != nil {
+		t.Fatalf("Invoke returned error: %v", err)
+	}
+
+	var result fsReadResult
+	if err := json.Unmarshal(out, &result); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	if result.Content != "hi" {
+		t.Fatalf("Content = %q, want %q", result.Content, "hi")
+	}
+}
+
+func TestFSReadRejectsEscapingPath(t *testing.T) {
+	dir := t.TempDir()
+	tool := &FSRead{Dir: dir}
+	args, _ := json.Marshal(fsReadArgs{Path: "../etc/passwd"})
+	if _, err := tool.Invoke(context.Background(), args); err == nil {
+		t.Fatal("expected error reading a path outside the configured root")
+	}
+}
@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTPGet fetches a URL and returns its status and body (at most 1 MiB of
+// it); response headers are not included in the result. There is
+// no allowlist here; callers that need to restrict destinations should wrap
+// this tool or check the URL before registering it.
+type HTTPGet struct {
+	Client *http.Client
+}
+
+func (t *HTTPGet) client() *http.Client {
+	if t.Client != nil {
+		return t.Client
+	}
+	return &http.Client{Timeout: 10 * time.Second}
+}
+
+func (t *HTTPGet) Name() string { return "http_get" }
+
+func (t *HTTPGet) Schema() json.RawMessage {
+	return json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"url": {"type": "string"}
+		},
+		"required": ["url"]
+	}`)
+}
+
+type httpGetArgs struct {
+	URL string `json:"url"`
+}
+
+type httpGetResult struct {
+	Status int    `json:"status"`
+	Body   string `json:"body"`
+}
+
+func (t *HTTPGet) Invoke(ctx context.Context, args json.RawMessage) (json.RawMessage, error) {
+	var in httpGetArgs
+	if err := json.Unmarshal(args, &in); err != nil {
+		return nil, fmt.Errorf("http_get: decode args: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, in.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("http_get: build request: %w", err)
+	}
+
+	resp, err := t.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http_get: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("http_get: read body: %w", err)
+	}
+
+	return json.Marshal(httpGetResult{Status: resp.StatusCode, Body: string(body)})
+}
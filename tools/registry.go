@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Registry holds the tools available to an agent and dispatches calls to
+// them by name.
+type Registry struct {
+	mu    sync.RWMutex
+	tools map[string]Tool
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{tools: make(map[string]Tool)}
+}
+
+// Register adds t to the registry, keyed by t.Name(). A later Register
+// with the same name replaces the earlier tool.
+func (r *Registry) Register(t Tool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tools[t.Name()] = t
+}
+
+// Invoke dispatches args to the named tool.
+func (r *Registry) Invoke(ctx context.Context, name string, args json.RawMessage) (json.RawMessage, error) {
+	r.mu.RLock()
+	t, ok := r.tools[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("tools: no tool registered for %q", name)
+	}
+	return t.Invoke(ctx, args)
+}
+
+// describedTool is the OpenAI "tools" array shape for a single function.
+type describedTool struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name       string          `json:"name"`
+		Parameters json.RawMessage `json:"parameters"`
+	} `json:"function"`
+}
+
+// Describe produces the OpenAI-style tools array advertising every
+// registered tool's name and schema.
+func (r *Registry) Describe() json.RawMessage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	described := make([]describedTool, 0, len(r.tools))
+	for _, t := range r.tools {
+		d := describedTool{Type: "function"}
+		d.Function.Name = t.Name()
+		d.Function.Parameters = t.Schema()
+		described = append(described, d)
+	}
+
+	out, err := json.Marshal(described)
+	if err != nil {
+		// Schemas come from Tool implementations we control; a marshal
+		// failure here means one of them returned invalid JSON.
+		panic(fmt.Sprintf("tools: marshal registry description: %v", err))
+	}
+	return out
+}
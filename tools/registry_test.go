@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type stubTool struct {
+	name string
+}
+
+func (s stubTool) Name() string            { return s.name }
+func (s stubTool) Schema() json.RawMessage { return json.RawMessage(`{"type":"object"}`) }
+func (s stubTool) Invoke(ctx context.Context, args json.RawMessage) (json.RawMessage, error) {
+	return json.RawMessage(`{"ok":true}`), nil
+}
+
+func TestRegistryInvoke(t *testing.T) {
+	r := NewRegistry()
+	r.Register(stubTool{name: "a"})
+
+	out, err := r.Invoke(context.Background(), "a", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("Invoke returned error: %v", err)
+	}
+	if string(out) != `{"ok":true}` {
+		t.Fatalf("Invoke() = %s, want %s", out, `{"ok":true}`)
+	}
+
+	if _, err := r.Invoke(context.Background(), "missing", nil); err == nil {
+		t.Fatal("expected error invoking unregistered tool")
+	}
+}
+
+func TestRegistryDescribe(t *testing.T) {
+	r := NewRegistry()
+	r.Register(stubTool{name: "a"})
+
+	desc := string(r.Describe())
+	if !strings.Contains(desc, `"name":"a"`) {
+		t.Fatalf("Describe() = %s, want it to mention tool %q", desc, "a")
+	}
+	if !strings.Contains(desc, `"type":"function"`) {
+		t.Fatalf("Describe() = %s, want OpenAI-style function entries", desc)
+	}
+}
@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+)
+
+// ShellExec runs a command from a fixed allowlist. The model supplies the
+// command name and arguments; anything not in Allowed is rejected before a
+// process is ever started.
+type ShellExec struct {
+	Allowed []string
+}
+
+func (t *ShellExec) Name() string { return "shell_exec" }
+
+func (t *ShellExec) Schema() json.RawMessage {
+	return json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"command": {"type": "string", "description": "the allowlisted command to run"},
+			"args": {"type": "array", "items": {"type": "string"}}
+		},
+		"required": ["command"]
+	}`)
+}
+
+type shellExecArgs struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+}
+
+type shellExecResult struct {
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	ExitCode int    `json:"exit_code"`
+}
+
+func (t *ShellExec) Invoke(ctx context.Context, args json.RawMessage) (json.RawMessage, error) {
+	var in shellExecArgs
+	if err := json.Unmarshal(args, &in); err != nil {
+		return nil, fmt.Errorf("shell_exec: decode args: %w", err)
+	}
+	if !t.isAllowed(in.Command) {
+		return nil, fmt.Errorf("shell_exec: command %q is not allowlisted", in.Command)
+	}
+
+	cmd := exec.CommandContext(ctx, in.Command, in.Args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	result := shellExecResult{}
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			result.ExitCode = exitErr.ExitCode()
+		} else {
+			return nil, fmt.Errorf("shell_exec: run %q: %w", in.Command, err)
+		}
+	}
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+
+	return json.Marshal(result)
+}
+
+func (t *ShellExec) isAllowed(command string) bool {
+	for _, allowed := range t.Allowed {
+		if allowed == command {
+			return true
+		}
+	}
+	return false
+}
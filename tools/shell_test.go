@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestShellExecRejectsUnallowedCommand(t *testing.T) {
+	tool := &ShellExec{Allowed: []string{"echo"}}
+	args, _ := json.Marshal(shellExecArgs{Command: "rm"})
+	if _, err := tool.Invoke(context.Background(), args); err == nil {
+		t.Fatal("expected error invoking a non-allowlisted command")
+	}
+}
+
+func TestShellExecRunsAllowedCommand(t *testing.T) {
+	tool := &ShellExec{Allowed: []string{"echo"}}
+	args, _ := json.Marshal(shellExecArgs{Command: "echo", Args: []string{"hi"}})
+
+	out, err := tool.Invoke(context.Background(), args)
+	if err != nil {
+		t.Fatalf("Invoke returned error: %v", err)
+	}
+
+	var result shellExecResult
+	if err := json.Unmarshal(out, &result); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	if result.Stdout != "hi\n" {
+		t.Fatalf("Stdout = %q, want %q", result.Stdout, "hi\n")
+	}
+}
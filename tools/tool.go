@@ -0,0 +1,20 @@
+// Package tools lets the agent invoke registered Go functions in response
+// to a provider's tool calls.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Tool is a single callable function exposed to the LLM.
+type Tool interface {
+	// Name is the identifier the model uses to request this tool.
+	Name() string
+	// Schema is the tool's parameters, as an OpenAI-style JSON Schema
+	// object.
+	Schema() json.RawMessage
+	// Invoke runs the tool with the given arguments and returns its
+	// JSON result.
+	Invoke(ctx context.Context, args json.RawMessage) (json.RawMessage, error)
+}
@@ -0,0 +1,139 @@
+package updater
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// download fetches url into a temp file in dir and returns its path.
+// The caller is responsible for removing it once it's no longer needed.
+func (u *Updater) download(ctx context.Context, dir, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("updater: build download request: %w", err)
+	}
+
+	resp, err := u.client().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("updater: download failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("updater: download returned status %d", resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp(dir, "goon-update-*")
+	if err != nil {
+		return "", fmt.Errorf("updater: create temp file: %w", err)
+	}
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("updater: write downloaded binary: %w", err)
+	}
+
+	return tmp.Name(), nil
+}
+
+// verifySHA256 checks path's contents hash to want (hex-encoded) and returns
+// the raw digest bytes for signature verification.
+func verifySHA256(path, want string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("updater: open downloaded binary: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("updater: hash downloaded binary: %w", err)
+	}
+
+	sum := h.Sum(nil)
+	got := hex.EncodeToString(sum)
+	if got != want {
+		return nil, fmt.Errorf("updater: sha256 mismatch: got %s, want %s", got, want)
+	}
+	return sum, nil
+}
+
+// verifySignature checks that sig (hex-encoded) is a valid ed25519
+// signature by pub over digest. This is the independent trust anchor: unlike
+// the sha256 in CheckResult, pub is baked into the caller rather than
+// supplied by ControlURL, so a compromised or spoofed control server cannot
+// forge a passing signature.
+func verifySignature(pub ed25519.PublicKey, digest []byte, sig string) error {
+	if len(pub) == 0 {
+		return fmt.Errorf("updater: no public key configured, refusing to apply an unsigned update")
+	}
+	raw, err := hex.DecodeString(sig)
+	if err != nil {
+		return fmt.Errorf("updater: decode signature: %w", err)
+	}
+	if !ed25519.Verify(pub, digest, raw) {
+		return fmt.Errorf("updater: signature verification failed")
+	}
+	return nil
+}
+
+// Apply downloads, verifies, and installs result, then re-execs the process
+// into the new binary. If the new binary exits nonzero within
+// rollbackWindow, the previous binary is restored.
+func (u *Updater) Apply(ctx context.Context, result CheckResult) error {
+	binaryPath := u.BinaryPath
+	if binaryPath == "" {
+		binaryPath = os.Args[0]
+	}
+	binaryPath, err := filepath.Abs(binaryPath)
+	if err != nil {
+		return fmt.Errorf("updater: resolve binary path: %w", err)
+	}
+
+	downloaded, err := u.download(ctx, filepath.Dir(binaryPath), result.DownloadURL)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(downloaded)
+
+	digest, err := verifySHA256(downloaded, result.SHA256)
+	if err != nil {
+		return err
+	}
+	if err := verifySignature(u.PublicKey, digest, result.Signature); err != nil {
+		return err
+	}
+	if err := os.Chmod(downloaded, 0o755); err != nil {
+		return fmt.Errorf("updater: chmod downloaded binary: %w", err)
+	}
+
+	backupPath, err := replaceBinary(binaryPath, downloaded)
+	if err != nil {
+		return fmt.Errorf("updater: install new binary: %w", err)
+	}
+
+	reexec := u.reexec
+	if reexec == nil {
+		reexec = func(ctx context.Context, binaryPath string, window time.Duration) error {
+			return reexecAndVerify(ctx, binaryPath, window, u.HandoffFile, u.HandoffFDEnv)
+		}
+	}
+	if err := reexec(ctx, binaryPath, rollbackWindow); err != nil {
+		if rbErr := rollback(binaryPath, backupPath); rbErr != nil {
+			return fmt.Errorf("updater: %w (rollback also failed: %v)", err, rbErr)
+		}
+		return fmt.Errorf("updater: %w (rolled back to previous binary)", err)
+	}
+
+	os.Remove(backupPath)
+	return ErrUpdated
+}
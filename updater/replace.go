@@ -0,0 +1,99 @@
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// rollbackWindow is how long a freshly re-exec'd binary has to keep running
+// before Apply considers the update successful.
+const rollbackWindow = 5 * time.Second
+
+// ErrUpdated is returned by Apply/CheckAndApply when a new binary was
+// installed and re-exec'd successfully. The caller owns the running
+// process at that point and should shut down gracefully and exit; the
+// child process is already serving.
+var ErrUpdated = errors.New("updater: applied update, this process should exit")
+
+// replaceBinary installs newPath over target and returns the path of a
+// backup of the previous binary, so a failed update can be rolled back.
+// Renaming target aside first (rather than deleting it) is what makes this
+// safe on Windows, where an executing binary can be renamed but not
+// overwritten in place.
+func replaceBinary(target, newPath string) (backupPath string, err error) {
+	backupPath = target + ".old"
+	os.Remove(backupPath) // best-effort; a stale backup must not block this update
+
+	if err := os.Rename(target, backupPath); err != nil {
+		return "", fmt.Errorf("back up current binary: %w", err)
+	}
+	if err := os.Rename(newPath, target); err != nil {
+		// Put the original back so the caller isn't left without a binary.
+		os.Rename(backupPath, target)
+		return "", fmt.Errorf("install new binary: %w", err)
+	}
+	return backupPath, nil
+}
+
+// rollback restores backupPath over target, undoing replaceBinary.
+func rollback(target, backupPath string) error {
+	os.Remove(target)
+	if err := os.Rename(backupPath, target); err != nil {
+		return fmt.Errorf("restore previous binary: %w", err)
+	}
+	return nil
+}
+
+// reexecAndVerify starts binaryPath as a child process with the current
+// args and environment, and waits up to window for it to exit. A child
+// that's still running when window elapses is considered healthy; one that
+// exits nonzero before then is not.
+//
+// If handoffFile is set, its result is duplicated into the child's file
+// descriptor table (as the first entry in ExtraFiles, i.e. fd 3) and
+// handoffFDEnv is set in the child's environment to that fd number. This is
+// what lets a still-serving daemon update itself: the caller hands the new
+// process a duplicate of its listening socket so both briefly share the
+// same open socket, instead of the child racing the still-running parent
+// for the port (which would always lose with an "address already in use").
+//
+// The child is not tied to ctx: it keeps running after the caller cancels
+// ctx to shut itself down once the update is reported.
+func reexecAndVerify(ctx context.Context, binaryPath string, window time.Duration, handoffFile func() (*os.File, error), handoffFDEnv string) error {
+	cmd := exec.Command(binaryPath, os.Args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+
+	if handoffFile != nil {
+		f, err := handoffFile()
+		if err != nil {
+			return fmt.Errorf("obtain handoff file: %w", err)
+		}
+		defer f.Close()
+		cmd.ExtraFiles = []*os.File{f}
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=3", handoffFDEnv))
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start new binary: %w", err)
+	}
+
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	select {
+	case err := <-exited:
+		if err != nil {
+			return fmt.Errorf("new binary exited during startup: %w", err)
+		}
+		return nil
+	case <-time.After(window):
+		return nil
+	}
+}
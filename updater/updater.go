@@ -0,0 +1,186 @@
+// Package updater checks a control-plane server for newer builds of goon,
+// downloads and verifies them, and replaces the running binary in place.
+package updater
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"time"
+)
+
+// HTTPClient is the subset of *http.Client the Updater needs, so tests can
+// substitute a fake transport.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Updater checks ControlURL for newer builds of the binary at BinaryPath
+// and applies them.
+type Updater struct {
+	ControlURL     string
+	AgentID        string
+	CurrentVersion string
+	BinaryPath     string
+	Client         HTTPClient
+
+	// PublicKey verifies the signature on every CheckResult before Apply
+	// installs it. ControlURL is not trusted as its own root of trust: a
+	// compromised or spoofed control server can already supply whatever
+	// sha256 it likes for its own download, so Apply refuses to install
+	// anything unless PublicKey is set and the signature checks out.
+	PublicKey ed25519.PublicKey
+
+	// HandoffFile, if set, is called right before Apply re-execs the new
+	// binary. Its result is duplicated into the child's file descriptors
+	// and HandoffFDEnv is set in the child's environment to the inherited
+	// fd number, so a caller holding a resource the child needs to take
+	// over (e.g. a listening socket) can hand it off instead of the child
+	// racing the still-running parent to reacquire it. Both are no-ops if
+	// HandoffFile is nil.
+	HandoffFile  func() (*os.File, error)
+	HandoffFDEnv string
+
+	NoAutoUpdate  bool
+	CheckInterval time.Duration
+
+	// reexec overrides how Apply re-execs into a newly installed binary.
+	// Tests substitute a fake so Apply can be exercised without spawning
+	// a real process.
+	reexec func(ctx context.Context, binaryPath string, window time.Duration) error
+}
+
+// checkRequest is POSTed to ControlURL.
+type checkRequest struct {
+	AgentID string `json:"agent_id"`
+	Version string `json:"version"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+}
+
+// CheckResult is the control server's answer to a version check.
+type CheckResult struct {
+	LatestVersion string `json:"latest_version"`
+	DownloadURL   string `json:"download_url"`
+	SHA256        string `json:"sha256"`
+	// Signature is an ed25519 signature (hex-encoded) over the raw SHA256
+	// digest bytes, produced by whoever signs releases with the private
+	// half of PublicKey. It is what makes the update trustworthy beyond
+	// "ControlURL says so".
+	Signature string `json:"signature"`
+}
+
+func (u *Updater) client() HTTPClient {
+	if u.Client != nil {
+		return u.Client
+	}
+	return http.DefaultClient
+}
+
+// Check asks the control server for the latest available version.
+func (u *Updater) Check(ctx context.Context) (CheckResult, error) {
+	payload, err := json.Marshal(checkRequest{
+		AgentID: u.AgentID,
+		Version: u.CurrentVersion,
+		OS:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+	})
+	if err != nil {
+		return CheckResult{}, fmt.Errorf("updater: encode check request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.ControlURL, bytes.NewReader(payload))
+	if err != nil {
+		return CheckResult{}, fmt.Errorf("updater: build check request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := u.client().Do(req)
+	if err != nil {
+		return CheckResult{}, fmt.Errorf("updater: check request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return CheckResult{}, fmt.Errorf("updater: control server returned status %d", resp.StatusCode)
+	}
+
+	var result CheckResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return CheckResult{}, fmt.Errorf("updater: decode check response: %w", err)
+	}
+	return result, nil
+}
+
+// HasUpdate reports whether result names a version newer than
+// u.CurrentVersion. A malformed version on either side is treated as "no
+// update" rather than erroring the whole check loop.
+func (u *Updater) HasUpdate(result CheckResult) bool {
+	latest, err := ParseVersion(result.LatestVersion)
+	if err != nil {
+		return false
+	}
+	current, err := ParseVersion(u.CurrentVersion)
+	if err != nil {
+		return false
+	}
+	return latest.Newer(current)
+}
+
+// CheckAndApply runs one check/apply cycle. It is the unit of work RunPeriodic
+// repeats, and what callers should invoke directly for an update-on-boot check.
+// It returns ErrUpdated if a new binary was installed and re-exec'd; the
+// caller should shut down and exit in that case.
+func (u *Updater) CheckAndApply(ctx context.Context) error {
+	if u.NoAutoUpdate {
+		return nil
+	}
+
+	result, err := u.Check(ctx)
+	if err != nil {
+		return err
+	}
+	if !u.HasUpdate(result) {
+		return nil
+	}
+	return u.Apply(ctx, result)
+}
+
+// RunPeriodic calls CheckAndApply every u.CheckInterval until ctx is
+// cancelled or an update is applied. A successful update stops the loop and
+// calls onUpdated so the caller can shut down and exit; any other error is
+// passed to onError and the loop keeps going.
+func (u *Updater) RunPeriodic(ctx context.Context, onUpdated func(), onError func(error)) {
+	if u.NoAutoUpdate || u.CheckInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(u.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := u.CheckAndApply(ctx)
+			switch {
+			case errors.Is(err, ErrUpdated):
+				if onUpdated != nil {
+					onUpdated()
+				}
+				return
+			case err != nil:
+				if onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package updater
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testSign signs digest with a freshly generated ed25519 key pair and
+// returns the hex-encoded signature alongside the public key, so Apply can
+// verify it the same way it would verify a real release signature.
+func testSign(t *testing.T, digest []byte) (ed25519.PublicKey, string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return pub, hex.EncodeToString(ed25519.Sign(priv, digest))
+}
+
+// fakeClient serves canned responses keyed by request path/method, letting
+// tests exercise Check/Apply without a real network.
+type fakeClient struct {
+	checkResult CheckResult
+	binary      []byte
+}
+
+func (f *fakeClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost {
+		body, _ := json.Marshal(f.checkResult)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body))}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(f.binary))}, nil
+}
+
+func TestCheckAndApplyNoUpdate(t *testing.T) {
+	u := &Updater{
+		CurrentVersion: "1.0.0",
+		Client:         &fakeClient{checkResult: CheckResult{LatestVersion: "1.0.0"}},
+	}
+	if err := u.CheckAndApply(context.Background()); err != nil {
+		t.Fatalf("CheckAndApply returned error: %v", err)
+	}
+}
+
+func TestCheckAndApplyInstallsNewerBinary(t *testing.T) {
+	dir := t.TempDir()
+	binaryPath := filepath.Join(dir, "goon")
+	if err := os.WriteFile(binaryPath, []byte("old binary"), 0o755); err != nil {
+		t.Fatalf("write old binary: %v", err)
+	}
+
+	newContents := []byte("new binary contents")
+	sum := sha256.Sum256(newContents)
+	pub, sig := testSign(t, sum[:])
+
+	u := &Updater{
+		CurrentVersion: "1.0.0",
+		BinaryPath:     binaryPath,
+		PublicKey:      pub,
+		Client: &fakeClient{
+			checkResult: CheckResult{
+				LatestVersion: "1.1.0",
+				DownloadURL:   "http://example.invalid/goon",
+				SHA256:        hex.EncodeToString(sum[:]),
+				Signature:     sig,
+			},
+			binary: newContents,
+		},
+		reexec: func(ctx context.Context, path string, window time.Duration) error {
+			return nil
+		},
+	}
+
+	err := u.CheckAndApply(context.Background())
+	if err != ErrUpdated {
+		t.Fatalf("CheckAndApply() error = %v, want ErrUpdated", err)
+	}
+
+	installed, err := os.ReadFile(binaryPath)
+	if err != nil {
+		t.Fatalf("read installed binary: %v", err)
+	}
+	if string(installed) != string(newContents) {
+		t.Fatalf("installed binary = %q, want %q", installed, newContents)
+	}
+	if _, err := os.Stat(binaryPath + ".old"); !os.IsNotExist(err) {
+		t.Fatalf("expected backup to be cleaned up after a successful update")
+	}
+}
+
+func TestCheckAndApplyRefusesUnsignedUpdate(t *testing.T) {
+	dir := t.TempDir()
+	binaryPath := filepath.Join(dir, "goon")
+	oldContents := []byte("old binary")
+	if err := os.WriteFile(binaryPath, oldContents, 0o755); err != nil {
+		t.Fatalf("write old binary: %v", err)
+	}
+
+	newContents := []byte("new binary contents")
+	sum := sha256.Sum256(newContents)
+
+	u := &Updater{
+		CurrentVersion: "1.0.0",
+		BinaryPath:     binaryPath,
+		// PublicKey intentionally left unset: Apply must refuse even
+		// though the sha256 matches, since that hash came from the same
+		// ControlURL response as everything else.
+		Client: &fakeClient{
+			checkResult: CheckResult{
+				LatestVersion: "1.1.0",
+				DownloadURL:   "http://example.invalid/goon",
+				SHA256:        hex.EncodeToString(sum[:]),
+			},
+			binary: newContents,
+		},
+		reexec: func(ctx context.Context, path string, window time.Duration) error {
+			return nil
+		},
+	}
+
+	if err := u.CheckAndApply(context.Background()); err == nil {
+		t.Fatal("expected error when no public key is configured")
+	}
+
+	restored, err := os.ReadFile(binaryPath)
+	if err != nil {
+		t.Fatalf("read binary: %v", err)
+	}
+	if string(restored) != string(oldContents) {
+		t.Fatalf("binary = %q, want untouched original %q", restored, oldContents)
+	}
+}
+
+func TestCheckAndApplyRollsBackOnUnhealthyChild(t *testing.T) {
+	dir := t.TempDir()
+	binaryPath := filepath.Join(dir, "goon")
+	oldContents := []byte("old binary")
+	if err := os.WriteFile(binaryPath, oldContents, 0o755); err != nil {
+		t.Fatalf("write old binary: %v", err)
+	}
+
+	newContents := []byte("broken new binary")
+	sum := sha256.Sum256(newContents)
+	pub, sig := testSign(t, sum[:])
+
+	u := &Updater{
+		CurrentVersion: "1.0.0",
+		BinaryPath:     binaryPath,
+		PublicKey:      pub,
+		Client: &fakeClient{
+			checkResult: CheckResult{
+				LatestVersion: "1.1.0",
+				DownloadURL:   "http://example.invalid/goon",
+				SHA256:        hex.EncodeToString(sum[:]),
+				Signature:     sig,
+			},
+			binary: newContents,
+		},
+		reexec: func(ctx context.Context, path string, window time.Duration) error {
+			return context.DeadlineExceeded
+		},
+	}
+
+	if err := u.CheckAndApply(context.Background()); err == nil {
+		t.Fatal("expected error when the new binary fails to start")
+	}
+
+	restored, err := os.ReadFile(binaryPath)
+	if err != nil {
+		t.Fatalf("read rolled-back binary: %v", err)
+	}
+	if string(restored) != string(oldContents) {
+		t.Fatalf("binary = %q, want original contents %q after rollback", restored, oldContents)
+	}
+}
@@ -0,0 +1,76 @@
+package updater
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a minimal major.minor.patch[-prerelease] semantic version.
+// This reimplements just the comparison goon needs locally rather than
+// pulling in a go-version dependency for three integers and one compare.
+type Version struct {
+	Major, Minor, Patch int
+	PreRelease          string
+}
+
+// ParseVersion parses strings like "1.2.3" or "v1.2.3-rc1".
+func ParseVersion(s string) (Version, error) {
+	s = strings.TrimPrefix(s, "v")
+	core, pre, _ := strings.Cut(s, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("updater: invalid version %q", s)
+	}
+
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return Version{}, fmt.Errorf("updater: invalid version %q: %w", s, err)
+		}
+		nums[i] = n
+	}
+
+	return Version{Major: nums[0], Minor: nums[1], Patch: nums[2], PreRelease: pre}, nil
+}
+
+// Compare returns -1, 0, or 1 as v is older than, equal to, or newer than
+// other. A version without a pre-release suffix is newer than the same
+// major.minor.patch with one.
+func (v Version) Compare(other Version) int {
+	if d := compareInt(v.Major, other.Major); d != 0 {
+		return d
+	}
+	if d := compareInt(v.Minor, other.Minor); d != 0 {
+		return d
+	}
+	if d := compareInt(v.Patch, other.Patch); d != 0 {
+		return d
+	}
+	switch {
+	case v.PreRelease == other.PreRelease:
+		return 0
+	case v.PreRelease == "":
+		return 1
+	case other.PreRelease == "":
+		return -1
+	default:
+		return strings.Compare(v.PreRelease, other.PreRelease)
+	}
+}
+
+// Newer reports whether v is strictly newer than other.
+func (v Version) Newer(other Version) bool { return v.Compare(other) > 0 }
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
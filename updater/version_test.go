@@ -0,0 +1,38 @@
+package updater
+
+import "testing"
+
+func TestVersionCompare(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.2.0", "1.10.0", -1},
+		{"2.0.0", "1.9.9", 1},
+		{"1.0.0-rc1", "1.0.0", -1},
+		{"1.0.0", "1.0.0-rc1", 1},
+	}
+	for _, c := range cases {
+		a, err := ParseVersion(c.a)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q): %v", c.a, err)
+		}
+		b, err := ParseVersion(c.b)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q): %v", c.b, err)
+		}
+		if got := a.Compare(b); got != c.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestParseVersionRejectsMalformed(t *testing.T) {
+	if _, err := ParseVersion("1.2"); err == nil {
+		t.Fatal("expected error for incomplete version")
+	}
+	if _, err := ParseVersion("a.b.c"); err == nil {
+		t.Fatal("expected error for non-numeric version")
+	}
+}